@@ -1,11 +1,11 @@
-// Package dbf provides parsing DBF files with cp1251 codepage(Cyrillic) for FoxBASE+/Dbase III plus, no memo
+// Package dbf provides parsing DBF files for FoxBASE+/Dbase III plus, with optional FPT memo support. Defaults to cp1251 (Cyrillic), but the codepage is pluggable and is auto-detected from the header's language driver byte when not set explicitly.
 package dbf
 
 import (
 	"bufio"
 	"encoding/binary"
 	"fmt"
-	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding"
 	"io"
 	"log"
 	"strconv"
@@ -18,15 +18,18 @@ import (
 // A dbf.Reader should have some metadata, and a Read() method that returns
 // table rows, one at a time
 type Reader struct {
-	rs           io.ReadSeeker
-	year         int
-	month        int
-	day          int
-	length       int // number of records
-	fields       []Field
-	headerLength uint16 // in bytes
-	recordLength uint16 // length of each record, in bytes
-	mu           sync.Mutex
+	rs            io.ReadSeeker
+	year          int
+	month         int
+	day           int
+	length        int // number of records
+	fields        []Field
+	headerLength  uint16 // in bytes
+	recordLength  uint16 // length of each record, in bytes
+	fpt           io.ReadSeeker
+	memoBlockSize uint16
+	enc           encoding.Encoding
+	mu            sync.Mutex
 }
 
 type header struct {
@@ -42,6 +45,23 @@ type header struct {
 
 // NewReader returns a new Reader reading from r.
 func NewReader(r io.ReadSeeker) (*Reader, error) {
+	return newReader(r, nil, nil)
+}
+
+// NewReaderWithMemo returns a new Reader reading from r, resolving M field
+// values against the companion FPT memo file fpt.
+func NewReaderWithMemo(r io.ReadSeeker, fpt io.ReadSeeker) (*Reader, error) {
+	return newReader(r, fpt, nil)
+}
+
+// NewReaderWithEncoding returns a new Reader reading from r, decoding C and M
+// field values with enc instead of the encoding inferred from the header's
+// language driver byte.
+func NewReaderWithEncoding(r io.ReadSeeker, enc encoding.Encoding) (*Reader, error) {
+	return newReader(r, nil, enc)
+}
+
+func newReader(r io.ReadSeeker, fpt io.ReadSeeker, enc encoding.Encoding) (*Reader, error) {
 	var h header
 	if _, err := r.Seek(0, 0); err != nil {
 		return nil, err
@@ -53,6 +73,17 @@ func NewReader(r io.ReadSeeker) (*Reader, error) {
 		return nil, fmt.Errorf("unexepected file Version: %d\n", h.Version)
 	}
 
+	if _, err := r.Seek(0x1D, 0); err != nil {
+		return nil, err
+	}
+	var langDriverID byte
+	if err := binary.Read(r, binary.LittleEndian, &langDriverID); err != nil {
+		return nil, err
+	}
+	if enc == nil {
+		enc = encodingForLangDriver(langDriverID)
+	}
+
 	var fields []Field
 	if _, err := r.Seek(0x20, 0); err != nil {
 		return nil, err
@@ -77,9 +108,20 @@ func NewReader(r io.ReadSeeker) (*Reader, error) {
 		return nil, fmt.Errorf("Header was supposed to be %d bytes long, but found byte %#x at that offset instead of expected byte 0x0D\n", h.HeaderLength, eoh)
 	}
 
-	return &Reader{rs: r, year: 1900 + int(h.Year),
+	rdr := &Reader{rs: r, year: 1900 + int(h.Year),
 		month: int(h.Month), day: int(h.Day), length: int(h.NumberRecord), fields: fields,
-		headerLength: h.HeaderLength, recordLength: h.RecordLength}, nil
+		headerLength: h.HeaderLength, recordLength: h.RecordLength, enc: enc}
+
+	if fpt != nil {
+		blockSize, err := readMemoHeader(fpt)
+		if err != nil {
+			return nil, err
+		}
+		rdr.fpt = fpt
+		rdr.memoBlockSize = blockSize
+	}
+
+	return rdr, nil
 }
 
 // ModDate return year, month and day of modification file
@@ -89,7 +131,7 @@ func (r *Reader) ModDate() (int, int, int) {
 
 // FieldName return name of ordinal number of the column
 func (r *Reader) FieldName(i int) (name string) {
-	return strings.TrimRight(string(r.fields[i].Name[:]), "\x00")
+	return r.fields[i].name()
 }
 
 // FieldNames return names of all columns
@@ -100,9 +142,14 @@ func (r *Reader) FieldNames() (names []string) {
 	return
 }
 
+// name returns the field's name with its trailing 0x00 padding trimmed.
+func (f Field) name() string {
+	return strings.TrimRight(string(f.Name[:]), "\x00")
+}
+
 func (f *Field) validate() error {
 	switch f.Type {
-	case 'C', 'N', 'F':
+	case 'C', 'N', 'F', 'M':
 		return nil
 	}
 	return fmt.Errorf("sorry, dbf library doesn't recognize field type '%c'", f.Type)
@@ -120,31 +167,42 @@ type Field struct {
 	_ [14]byte
 }
 
-type Record map[string]interface{}
+// Record is a single DBF row. Values holds the decoded field values keyed
+// by field name; Deleted reports whether the row is marked as deleted (dBase
+// soft-deletes rows by flagging them rather than removing them immediately).
+type Record struct {
+	Deleted bool
+	Values  map[string]interface{}
+}
 
-// Read implements the Reader interface only for C,N,F types of record in a file
-func (r *Reader) Read(i uint16) (rec Record, err error) {
+// Read implements the Reader interface only for C,N,F,M types of record in a file
+func (r *Reader) Read(i uint32) (rec Record, err error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	offset := int64(r.headerLength) + int64(r.recordLength)*int64(i)
 	if _, err = r.rs.Seek(offset, 0); err != nil {
 		log.Println("seek error")
 	}
+	return r.parseRecord(r.rs)
+}
 
+// parseRecord reads a single record (the deleted flag followed by the field
+// values) from src, which must already be positioned at the start of a row.
+func (r *Reader) parseRecord(src io.Reader) (rec Record, err error) {
 	var deleted byte
-	if err = binary.Read(r.rs, binary.LittleEndian, &deleted); err != nil {
-		return nil, err
+	if err = binary.Read(src, binary.LittleEndian, &deleted); err != nil {
+		return Record{}, err
 	} else if deleted == '*' {
-		return nil, fmt.Errorf("record %d is deleted", i)
+		rec.Deleted = true
 	} else if deleted != ' ' {
-		return nil, fmt.Errorf("record %d contained an unexpected value in the deleted flag: %v", i, deleted)
+		return Record{}, fmt.Errorf("record contained an unexpected value in the deleted flag: %v", deleted)
 	}
 
-	rec = make(Record)
+	rec.Values = make(map[string]interface{})
 	for i, f := range r.fields {
 		buf := make([]byte, f.Len)
-		if err = binary.Read(r.rs, binary.LittleEndian, &buf); err != nil {
-			return nil, err
+		if err = binary.Read(src, binary.LittleEndian, &buf); err != nil {
+			return Record{}, err
 		}
 
 		fieldVal := strings.TrimSpace(string(buf))
@@ -153,30 +211,32 @@ func (r *Reader) Read(i uint16) (rec Record, err error) {
 		switch f.Type {
 		case 'F':
 			if len(fieldVal) == 0 {
-				rec[fieldName] = float64(0)
+				rec.Values[fieldName] = float64(0)
 			} else {
-				rec[fieldName], err = strconv.ParseFloat(fieldVal, 64)
+				rec.Values[fieldName], err = strconv.ParseFloat(fieldVal, 64)
 			}
 		case 'N':
 			if len(fieldVal) == 0 {
-				rec[fieldName] = 0
+				rec.Values[fieldName] = 0
 			} else if f.DecimalPlaces > 0 {
-				rec[fieldName], err = strconv.ParseFloat(fieldVal, 64)
+				rec.Values[fieldName], err = strconv.ParseFloat(fieldVal, 64)
 			} else {
-				rec[fieldName], err = strconv.Atoi(fieldVal)
+				rec.Values[fieldName], err = strconv.Atoi(fieldVal)
 			}
 		case 'C':
 			if len(fieldVal) == 0 {
-				rec[fieldName] = ""
+				rec.Values[fieldName] = ""
 			} else {
-				decoder := charmap.Windows1251.NewDecoder()
-				rec[fieldName], err = decoder.String(fieldVal)
+				decoder := r.enc.NewDecoder()
+				rec.Values[fieldName], err = decoder.String(fieldVal)
 			}
+		case 'M':
+			rec.Values[fieldName], err = r.readMemo(buf, f)
 		default:
-			rec[fieldName] = fieldVal
+			rec.Values[fieldName] = fieldVal
 		}
 		if err != nil {
-			return nil, err
+			return Record{}, err
 		}
 	}
 	return rec, nil
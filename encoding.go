@@ -0,0 +1,53 @@
+package dbf
+
+import (
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// langDriverEncodings maps the language driver ID stored at header offset
+// 0x1D to the code page it designates, for the IDs commonly seen in the
+// wild. Unlisted or absent IDs fall back to cp1251.
+var langDriverEncodings = map[byte]encoding.Encoding{
+	0x01: charmap.CodePage437, // U.S. MS-DOS
+	0x02: charmap.CodePage850, // International MS-DOS
+	0x26: charmap.CodePage866, // Russian MS-DOS (OEM)
+	0xC8: charmap.Windows1250, // Eastern European Windows
+	0xC9: charmap.Windows1251, // Russian Windows
+	0x03: charmap.Windows1252, // Windows ANSI
+}
+
+// encodingForLangDriver returns the default encoding for the given language
+// driver ID, falling back to cp1251 when the ID is unknown or 0x00.
+func encodingForLangDriver(langDriverID byte) encoding.Encoding {
+	if enc, ok := langDriverEncodings[langDriverID]; ok {
+		return enc
+	}
+	return charmap.Windows1251
+}
+
+// langDriverForEncodingIDs is the reverse of langDriverEncodings, used by
+// Writer to stamp the header with the codepage it encoded C fields with.
+var langDriverForEncodingIDs = func() map[encoding.Encoding]byte {
+	m := make(map[encoding.Encoding]byte, len(langDriverEncodings))
+	for id, enc := range langDriverEncodings {
+		m[enc] = id
+	}
+	return m
+}()
+
+// langDriverForEncoding returns the language driver ID for enc, falling
+// back to the cp1251 ID when enc is nil or not one of the known codepages.
+func langDriverForEncoding(enc encoding.Encoding) byte {
+	if id, ok := langDriverForEncodingIDs[enc]; ok {
+		return id
+	}
+	return 0xC9
+}
+
+// SetEncoding changes the encoding used to decode C and M field values.
+func (r *Reader) SetEncoding(enc encoding.Encoding) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.enc = enc
+}
@@ -0,0 +1,77 @@
+package dbf
+
+import (
+	"bufio"
+	"io"
+)
+
+// Iterator streams records from a Reader without the per-row seek overhead
+// of Read, following the bufio.Scanner pattern: call Scan in a loop and read
+// the current row with Record once Scan returns true.
+//
+// An Iterator takes over positioning of the Reader's underlying
+// io.ReadSeeker, so it must not be used concurrently with Read or another
+// Iterator from the same Reader.
+type Iterator struct {
+	r           *Reader
+	br          *bufio.Reader
+	row         uint32
+	skipDeleted bool
+	rec         Record
+	err         error
+}
+
+// IteratorOption configures an Iterator returned by Reader.Iterator.
+type IteratorOption func(*Iterator)
+
+// SkipDeleted makes Scan silently skip over rows flagged as deleted instead
+// of surfacing them with Record.Deleted set.
+func SkipDeleted() IteratorOption {
+	return func(it *Iterator) { it.skipDeleted = true }
+}
+
+// Iterator returns an Iterator positioned at the first record of r.
+func (r *Reader) Iterator(opts ...IteratorOption) (*Iterator, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, err := r.rs.Seek(int64(r.headerLength), 0); err != nil {
+		return nil, err
+	}
+
+	it := &Iterator{r: r, br: bufio.NewReader(r.rs)}
+	for _, opt := range opts {
+		opt(it)
+	}
+	return it, nil
+}
+
+// Scan advances the Iterator to the next record, returning false once the
+// table is exhausted or an error occurs; check Err after Scan returns false.
+func (it *Iterator) Scan() bool {
+	for it.row < uint32(it.r.length) {
+		rec, err := it.r.parseRecord(it.br)
+		it.row++
+		if err != nil {
+			if err != io.EOF {
+				it.err = err
+			}
+			return false
+		}
+		if rec.Deleted && it.skipDeleted {
+			continue
+		}
+		it.rec = rec
+		return true
+	}
+	return false
+}
+
+// Record returns the row most recently produced by Scan.
+func (it *Iterator) Record() Record {
+	return it.rec
+}
+
+// Err returns the first non-EOF error encountered by Scan, if any.
+func (it *Iterator) Err() error {
+	return it.err
+}
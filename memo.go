@@ -0,0 +1,94 @@
+package dbf
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// fptHeader is read from the 512-byte header found at the start of an FPT
+// memo file; the remaining bytes of the header are reserved and ignored.
+type fptHeader struct {
+	NextFreeBlock uint32
+	_             [2]byte
+	BlockSize     uint16
+}
+
+// blockHeader precedes every memo block payload.
+type blockHeader struct {
+	Type   uint32 // 0 = picture, 1 = text
+	Length uint32
+}
+
+// readMemoHeader reads the FPT file header and returns the memo block size.
+func readMemoHeader(fpt io.ReadSeeker) (uint16, error) {
+	if _, err := fpt.Seek(0, 0); err != nil {
+		return 0, err
+	}
+	var h fptHeader
+	if err := binary.Read(fpt, binary.BigEndian, &h); err != nil {
+		return 0, err
+	}
+	if h.BlockSize == 0 {
+		return 0, fmt.Errorf("memo file reports a block size of 0")
+	}
+	return h.BlockSize, nil
+}
+
+// readMemo resolves the block pointer stored in buf (the raw bytes of an M
+// field) to the memo text it references.
+func (r *Reader) readMemo(buf []byte, f Field) (string, error) {
+	if r.fpt == nil {
+		return "", fmt.Errorf("field is of type M but no memo file was supplied, see NewReaderWithMemo")
+	}
+
+	block, err := parseMemoPointer(buf, f.Len)
+	if err != nil {
+		return "", err
+	}
+	if block == 0 {
+		return "", nil
+	}
+
+	offset := int64(block) * int64(r.memoBlockSize)
+	if _, err := r.fpt.Seek(offset, 0); err != nil {
+		return "", err
+	}
+
+	var bh blockHeader
+	if err := binary.Read(r.fpt, binary.BigEndian, &bh); err != nil {
+		return "", err
+	}
+
+	payload := make([]byte, bh.Length)
+	if err := binary.Read(r.fpt, binary.BigEndian, &payload); err != nil {
+		return "", err
+	}
+
+	decoder := r.enc.NewDecoder()
+	return decoder.String(string(payload))
+}
+
+// parseMemoPointer decodes the block number stored in an M field, which is
+// either a 10-byte ASCII number (FoxBASE-style) or a 4-byte little-endian
+// uint32 (FoxPro-style), depending on the field's declared length.
+func parseMemoPointer(buf []byte, length uint8) (uint32, error) {
+	switch length {
+	case 10:
+		s := strings.TrimSpace(string(buf))
+		if s == "" {
+			return 0, nil
+		}
+		n, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			return 0, fmt.Errorf("invalid FoxBASE memo pointer %q: %w", s, err)
+		}
+		return uint32(n), nil
+	case 4:
+		return binary.LittleEndian.Uint32(buf), nil
+	default:
+		return 0, fmt.Errorf("unsupported memo field length %d, expected 4 or 10", length)
+	}
+}
@@ -0,0 +1,150 @@
+package dbf
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ReadInto reads record i into the struct pointed to by v, matching DBF
+// columns to struct fields via `dbf:"FIELDNAME"` tags and falling back to a
+// case-insensitive match on the field name.
+func (r *Reader) ReadInto(i uint32, v interface{}) error {
+	rec, err := r.Read(i)
+	if err != nil {
+		return err
+	}
+	return populateStruct(rec, v)
+}
+
+// ReadAll reads every non-deleted record into the slice pointed to by dst,
+// which must be a pointer to a slice of structs.
+func (r *Reader) ReadAll(dst interface{}) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Ptr || dv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("dbf: ReadAll requires a pointer to a slice, got %T", dst)
+	}
+	sliceVal := dv.Elem()
+	elemType := sliceVal.Type().Elem()
+
+	it, err := r.Iterator(SkipDeleted())
+	if err != nil {
+		return err
+	}
+	for it.Scan() {
+		elemPtr := reflect.New(elemType)
+		if err := populateStruct(it.Record(), elemPtr.Interface()); err != nil {
+			return err
+		}
+		sliceVal.Set(reflect.Append(sliceVal, elemPtr.Elem()))
+	}
+	return it.Err()
+}
+
+// populateStruct fills the struct pointed to by v with rec's values.
+func populateStruct(rec Record, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("dbf: target must be a pointer to a struct, got %T", v)
+	}
+	structVal := rv.Elem()
+	structType := structVal.Type()
+
+	for name, raw := range rec.Values {
+		idx := findStructField(structType, name)
+		if idx < 0 {
+			continue
+		}
+		fieldVal := structVal.Field(idx)
+		if !fieldVal.CanSet() {
+			continue
+		}
+		if err := setFieldValue(fieldVal, raw); err != nil {
+			return fmt.Errorf("dbf: field %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// findStructField returns the index of the field of t that corresponds to
+// the DBF column name, preferring a `dbf` tag match over a name match on an
+// untagged field; both comparisons ignore case. It returns -1 if there is no
+// match.
+func findStructField(t reflect.Type, name string) int {
+	for i := 0; i < t.NumField(); i++ {
+		if tag := t.Field(i).Tag.Get("dbf"); tag != "" && strings.EqualFold(tag, name) {
+			return i
+		}
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Tag.Get("dbf") == "" && strings.EqualFold(f.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// setFieldValue converts raw (a value decoded by Reader.Read) into
+// fieldVal's type, returning an error when the conversion is not supported.
+func setFieldValue(fieldVal reflect.Value, raw interface{}) error {
+	switch fieldVal.Kind() {
+	case reflect.String:
+		s, ok := raw.(string)
+		if !ok {
+			return fmt.Errorf("cannot assign %T to a string field", raw)
+		}
+		fieldVal.SetString(s)
+	case reflect.Bool:
+		s, ok := raw.(string)
+		if !ok {
+			return fmt.Errorf("cannot assign %T to a bool field", raw)
+		}
+		switch strings.ToUpper(strings.TrimSpace(s)) {
+		case "T", "Y":
+			fieldVal.SetBool(true)
+		case "F", "N", "":
+			fieldVal.SetBool(false)
+		default:
+			return fmt.Errorf("cannot parse %q as a bool", s)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := numericValue(raw)
+		if err != nil {
+			return err
+		}
+		fieldVal.SetInt(int64(n))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := numericValue(raw)
+		if err != nil {
+			return err
+		}
+		if n < 0 {
+			return fmt.Errorf("cannot assign negative value %v to an unsigned field", n)
+		}
+		fieldVal.SetUint(uint64(n))
+	case reflect.Float32, reflect.Float64:
+		n, err := numericValue(raw)
+		if err != nil {
+			return err
+		}
+		fieldVal.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported target kind %s", fieldVal.Kind())
+	}
+	return nil
+}
+
+// numericValue extracts the numeric value decoded for an N or F field.
+func numericValue(raw interface{}) (float64, error) {
+	switch v := raw.(type) {
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	default:
+		return 0, fmt.Errorf("cannot assign %T to a numeric field", raw)
+	}
+}